@@ -0,0 +1,77 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mountinfo
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// isPathOrChild returns true if p is dir itself or a path below it. dir is
+// assumed to already be clean, i.e. without a trailing separator (except
+// for the root itself).
+func isPathOrChild(dir, p string) bool {
+	if p == dir {
+		return true
+	}
+	if dir == string(filepath.Separator) {
+		return strings.HasPrefix(p, dir)
+	}
+	return strings.HasPrefix(p, dir+string(filepath.Separator))
+}
+
+// PrefixFilter keeps only the entries whose MountPoint is path, or a
+// descendant of it. It never stops early, since multiple mounts can be
+// nested under the same prefix.
+func PrefixFilter(path string) FilterFunc {
+	path = filepath.Clean(path)
+	return func(info *MountInfo) (skip, stop bool) {
+		return !isPathOrChild(path, info.MountPoint), false
+	}
+}
+
+// SingleEntryFilter keeps only the entry whose MountPoint is exactly path,
+// and stops reading as soon as it is found.
+func SingleEntryFilter(path string) FilterFunc {
+	path = filepath.Clean(path)
+	return func(info *MountInfo) (skip, stop bool) {
+		if info.MountPoint == path {
+			return false, true
+		}
+		return true, false
+	}
+}
+
+// ParentsFilter keeps only the entries whose MountPoint is path or one of
+// its parent directories, the set of mounts relevant to answering "what
+// filesystem backs path".
+func ParentsFilter(path string) FilterFunc {
+	path = filepath.Clean(path)
+	return func(info *MountInfo) (skip, stop bool) {
+		return !isPathOrChild(info.MountPoint, path), false
+	}
+}
+
+// FSTypeFilter keeps only the entries whose FilesystemType is one of types.
+func FSTypeFilter(types ...string) FilterFunc {
+	return func(info *MountInfo) (skip, stop bool) {
+		for _, t := range types {
+			if info.FilesystemType == t {
+				return false, false
+			}
+		}
+		return true, false
+	}
+}
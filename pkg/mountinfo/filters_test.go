@@ -0,0 +1,113 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mountinfo
+
+import "testing"
+
+func TestPrefixFilterSiblingPaths(t *testing.T) {
+	// /mnt/foobar shares the literal string prefix "/mnt/foo" but is not
+	// a child of it, and must not be kept.
+	f := PrefixFilter("/mnt/foo")
+
+	skip, _ := f(&MountInfo{MountPoint: "/mnt/foo"})
+	if skip {
+		t.Error("PrefixFilter(/mnt/foo): /mnt/foo itself was skipped")
+	}
+
+	skip, _ = f(&MountInfo{MountPoint: "/mnt/foo/bar"})
+	if skip {
+		t.Error("PrefixFilter(/mnt/foo): /mnt/foo/bar was skipped")
+	}
+
+	skip, _ = f(&MountInfo{MountPoint: "/mnt/foobar"})
+	if !skip {
+		t.Error("PrefixFilter(/mnt/foo): sibling /mnt/foobar was not skipped")
+	}
+}
+
+func TestPrefixFilterRoot(t *testing.T) {
+	// "/" is a prefix of every mount point, not just a literal "/" entry.
+	f := PrefixFilter("/")
+
+	skip, _ := f(&MountInfo{MountPoint: "/"})
+	if skip {
+		t.Error("PrefixFilter(/): / itself was skipped")
+	}
+
+	skip, _ = f(&MountInfo{MountPoint: "/proc"})
+	if skip {
+		t.Error("PrefixFilter(/): /proc was skipped")
+	}
+}
+
+func TestParentsFilterRoot(t *testing.T) {
+	// The root mount is an ancestor of every path, even when no other
+	// mount matches.
+	f := ParentsFilter("/some/deep/path")
+
+	skip, _ := f(&MountInfo{MountPoint: "/"})
+	if skip {
+		t.Error("ParentsFilter(/some/deep/path): root mount was skipped")
+	}
+}
+
+func TestParentsFilterSiblingPaths(t *testing.T) {
+	// A mount point of /mnt/foobar is not a parent of /mnt/foo/bar even
+	// though it shares the same literal string prefix.
+	f := ParentsFilter("/mnt/foo/bar")
+
+	skip, _ := f(&MountInfo{MountPoint: "/mnt/foo"})
+	if skip {
+		t.Error("ParentsFilter(/mnt/foo/bar): parent /mnt/foo was skipped")
+	}
+
+	skip, _ = f(&MountInfo{MountPoint: "/mnt/foo/bar"})
+	if skip {
+		t.Error("ParentsFilter(/mnt/foo/bar): path itself was skipped")
+	}
+
+	skip, _ = f(&MountInfo{MountPoint: "/mnt/foobar"})
+	if !skip {
+		t.Error("ParentsFilter(/mnt/foo/bar): sibling /mnt/foobar was not skipped")
+	}
+}
+
+func TestSingleEntryFilter(t *testing.T) {
+	f := SingleEntryFilter("/mnt/foo")
+
+	skip, stop := f(&MountInfo{MountPoint: "/mnt/foobar"})
+	if !skip || stop {
+		t.Errorf("SingleEntryFilter(/mnt/foo) on /mnt/foobar: skip=%v stop=%v, want skip=true stop=false", skip, stop)
+	}
+
+	skip, stop = f(&MountInfo{MountPoint: "/mnt/foo"})
+	if skip || !stop {
+		t.Errorf("SingleEntryFilter(/mnt/foo) on /mnt/foo: skip=%v stop=%v, want skip=false stop=true", skip, stop)
+	}
+}
+
+func TestFSTypeFilter(t *testing.T) {
+	f := FSTypeFilter("bpf", "cgroup2")
+
+	skip, _ := f(&MountInfo{FilesystemType: "bpf"})
+	if skip {
+		t.Error("FSTypeFilter(bpf, cgroup2): bpf entry was skipped")
+	}
+
+	skip, _ = f(&MountInfo{FilesystemType: "tmpfs"})
+	if !skip {
+		t.Error("FSTypeFilter(bpf, cgroup2): tmpfs entry was not skipped")
+	}
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mountinfo
+
+import (
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// devKey identifies a filesystem by its st_dev major/minor pair, as found
+// in a stat(2) result's Dev field.
+type devKey struct {
+	major, minor uint32
+}
+
+// MountIndex allows looking up a *MountInfo without rescanning
+// /proc/self/mountinfo, for callers that need to repeatedly answer "what
+// filesystem backs this file" from a stat(2) result or a mount ID.
+type MountIndex struct {
+	byDev        map[devKey]*MountInfo
+	byMountID    map[int64]*MountInfo
+	byMountPoint map[string]*MountInfo
+}
+
+// BuildMountIndex builds a MountIndex from a slice of *MountInfo, typically
+// obtained from GetMountInfo.
+func BuildMountIndex(mounts []*MountInfo) *MountIndex {
+	idx := &MountIndex{
+		byDev:        make(map[devKey]*MountInfo, len(mounts)),
+		byMountID:    make(map[int64]*MountInfo, len(mounts)),
+		byMountPoint: make(map[string]*MountInfo, len(mounts)),
+	}
+
+	for _, m := range mounts {
+		idx.byDev[devKey{m.Major, m.Minor}] = m
+		idx.byMountID[m.MountID] = m
+		idx.byMountPoint[filepath.Clean(m.MountPoint)] = m
+	}
+
+	return idx
+}
+
+// LookupByDev returns the *MountInfo whose Major and Minor match dev, the
+// raw device number from a stat(2) result (e.g.
+// os.Stat(path).Sys().(*syscall.Stat_t).Dev).
+func (idx *MountIndex) LookupByDev(dev uint64) (*MountInfo, bool) {
+	m, ok := idx.byDev[devKey{unix.Major(dev), unix.Minor(dev)}]
+	return m, ok
+}
+
+// LookupByMountID returns the *MountInfo with the given mount ID.
+func (idx *MountIndex) LookupByMountID(mountID int64) (*MountInfo, bool) {
+	m, ok := idx.byMountID[mountID]
+	return m, ok
+}
+
+// LookupByPath walks path and its parent directories until it finds the
+// longest-matching MountPoint in the index, i.e. the mount that backs
+// path.
+func (idx *MountIndex) LookupByPath(path string) (*MountInfo, bool) {
+	path = filepath.Clean(path)
+
+	for {
+		if m, ok := idx.byMountPoint[path]; ok {
+			return m, true
+		}
+
+		parent := filepath.Dir(path)
+		if parent == path {
+			return nil, false
+		}
+		path = parent
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mountinfo
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestParseStDev(t *testing.T) {
+	tests := []struct {
+		in        string
+		major     uint32
+		minor     uint32
+		expectErr bool
+	}{
+		{in: "0:3", major: 0, minor: 3},
+		{in: "8:1", major: 8, minor: 1},
+		// A major >= 4096 exercises the high bits of dev_t, which a
+		// naive 12-bit decode would truncate.
+		{in: "4200:5", major: 4200, minor: 5},
+		{in: "bogus", expectErr: true},
+		{in: "1:bogus", expectErr: true},
+	}
+
+	for _, tt := range tests {
+		major, minor, err := parseStDev(tt.in)
+		if tt.expectErr {
+			if err == nil {
+				t.Errorf("parseStDev(%q): expected error, got none", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseStDev(%q): unexpected error: %s", tt.in, err)
+			continue
+		}
+		if major != tt.major || minor != tt.minor {
+			t.Errorf("parseStDev(%q) = (%d, %d), want (%d, %d)", tt.in, major, minor, tt.major, tt.minor)
+		}
+	}
+}
+
+func TestMountIndexLookupByDev(t *testing.T) {
+	const major, minor = 4200, 5
+
+	mounts := []*MountInfo{
+		{MountID: 1, Major: major, Minor: minor, MountPoint: "/mnt/big-major"},
+	}
+	idx := BuildMountIndex(mounts)
+
+	dev := unix.Mkdev(major, minor)
+	got, ok := idx.LookupByDev(dev)
+	if !ok {
+		t.Fatalf("LookupByDev(%d): not found", dev)
+	}
+	if got.MountPoint != "/mnt/big-major" {
+		t.Errorf("LookupByDev(%d) = %+v, want MountPoint /mnt/big-major", dev, got)
+	}
+}
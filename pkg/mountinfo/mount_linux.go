@@ -0,0 +1,210 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package mountinfo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// Mount mounts source onto target with the given filesystem type, flags
+// and data, wrapping unix.Mount.
+func Mount(source, target, fstype string, flags uintptr, data string) error {
+	if err := unix.Mount(source, target, fstype, flags, data); err != nil {
+		return &os.PathError{Op: "mount", Path: target, Err: err}
+	}
+	return nil
+}
+
+// Unmount wraps unix.Unmount.
+func Unmount(target string, flags int) error {
+	if err := unix.Unmount(target, flags); err != nil {
+		return &os.PathError{Op: "unmount", Path: target, Err: err}
+	}
+	return nil
+}
+
+// MakeShared marks the mount at path, and everything mounted under it, as
+// shared, so that new mounts under it propagate to other shared mounts of
+// the same peer group.
+func MakeShared(path string) error {
+	return changeMountPropagation(path, unix.MS_SHARED|unix.MS_REC)
+}
+
+// MakePrivate marks the mount at path as private, i.e. mount and unmount
+// events under it no longer propagate to or from its peer group.
+func MakePrivate(path string) error {
+	return changeMountPropagation(path, unix.MS_PRIVATE)
+}
+
+// MakeRUnbindable recursively marks the mount at path, and every mount
+// under it, unbindable, so that it (and its submounts) can no longer be
+// the source of a bind mount.
+func MakeRUnbindable(path string) error {
+	return changeMountPropagation(path, unix.MS_UNBINDABLE|unix.MS_REC)
+}
+
+func changeMountPropagation(path string, flag uintptr) error {
+	if err := unix.Mount("", path, "", flag, ""); err != nil {
+		return &os.PathError{Op: "mount", Path: path, Err: err}
+	}
+	return nil
+}
+
+// EnsureMount makes sure fstype is mounted at target, creating target and
+// mounting fstype there if it is not already mounted, then verifies that
+// the resulting superblock's magic number is magic (one of the
+// FilesystemType* constants). It is safe to call repeatedly; if target is
+// already the expected mount, EnsureMount does nothing.
+func EnsureMount(target, fstype string, magic int64) error {
+	mounted, err := Mounted(target)
+	if err != nil {
+		return err
+	}
+
+	if !mounted {
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return fmt.Errorf("failed to create mount target %s: %s", target, err)
+		}
+		if err := Mount(fstype, target, fstype, 0, ""); err != nil {
+			return err
+		}
+	}
+
+	fst := unix.Statfs_t{}
+	if err := unix.Statfs(target, &fst); err != nil {
+		return &os.PathError{Op: "statfs", Path: target, Err: err}
+	}
+	if fst.Type != magic {
+		return fmt.Errorf("%s is mounted but is not of the expected filesystem type", target)
+	}
+
+	return nil
+}
+
+// Mounted returns whether path is a mount point. Unlike IsMountFS, it
+// detects bind mounts and works for path="/".
+//
+// It first tries to open path with openat2(2) and RESOLVE_NO_XDEV: on
+// Linux 5.6+ the kernel refuses to cross a mount boundary while resolving
+// the path and returns EXDEV, which tells us path is a mount point without
+// reading /proc/self/mountinfo at all. If openat2 is not available
+// (ENOSYS), it falls back to comparing path's device against its parent's,
+// and to scanning mountinfo for a matching entry so that bind mounts are
+// still recognized.
+func Mounted(path string) (bool, error) {
+	clean := filepath.Clean(path)
+	if filepath.Dir(clean) == clean {
+		// openat2+RESOLVE_NO_XDEV only detects a mount boundary while
+		// crossing from a parent into the target component, so it can
+		// never see a boundary at the root itself. Go straight to the
+		// mountinfo-based fallback.
+		return mountedSlow(clean)
+	}
+
+	mounted, err := mountedFast(path)
+	if err == nil {
+		return mounted, nil
+	}
+	if !errors.Is(err, unix.ENOSYS) {
+		return false, err
+	}
+
+	return mountedSlow(path)
+}
+
+// MountedType is like Mounted, but additionally reports whether the mount's
+// filesystem type is mntType, one of the FilesystemType* magic numbers.
+func MountedType(path string, mntType int64) (mounted, matches bool, err error) {
+	mounted, err = Mounted(path)
+	if err != nil || !mounted {
+		return mounted, false, err
+	}
+
+	fst := unix.Statfs_t{}
+	if err := unix.Statfs(path, &fst); err != nil {
+		return true, false, &os.PathError{Op: "statfs", Path: path, Err: err}
+	}
+
+	return true, fst.Type == mntType, nil
+}
+
+// mountedFast implements the openat2-based fast path of Mounted. It returns
+// unix.ENOSYS when openat2 is not supported by the running kernel.
+func mountedFast(path string) (bool, error) {
+	clean := filepath.Clean(path)
+	dir := filepath.Dir(clean)
+	dirfd, err := unix.Openat2(unix.AT_FDCWD, dir, &unix.OpenHow{
+		Flags: unix.O_PATH | unix.O_CLOEXEC,
+	})
+	if err != nil {
+		if errors.Is(err, unix.ENOENT) {
+			return false, nil
+		}
+		return false, &os.PathError{Op: "openat2", Path: dir, Err: err}
+	}
+	fd, err := unix.Openat2(dirfd, filepath.Base(clean), &unix.OpenHow{
+		Flags:   unix.O_PATH | unix.O_CLOEXEC | unix.O_NOFOLLOW,
+		Resolve: unix.RESOLVE_NO_XDEV,
+	})
+	unix.Close(dirfd)
+	if err == nil {
+		unix.Close(fd)
+		return false, nil
+	}
+	if errors.Is(err, unix.EXDEV) {
+		return true, nil
+	}
+	if errors.Is(err, unix.ENOENT) {
+		// non-existent path can't be a mount point
+		return false, nil
+	}
+	return false, &os.PathError{Op: "openat2", Path: path, Err: err}
+}
+
+// mountedSlow is the pre-5.6-kernel fallback for Mounted: it compares the
+// device of path against its parent's, and additionally scans
+// /proc/self/mountinfo so that bind mounts -- which do not change the
+// device number -- are still recognized.
+func mountedSlow(path string) (bool, error) {
+	var st, pst unix.Stat_t
+
+	if err := unix.Lstat(path, &st); err != nil {
+		if errors.Is(err, unix.ENOENT) {
+			return false, nil
+		}
+		return false, &os.PathError{Op: "lstat", Path: path, Err: err}
+	}
+
+	parent := filepath.Dir(path)
+	if err := unix.Lstat(parent, &pst); err != nil {
+		return false, &os.PathError{Op: "lstat", Path: parent, Err: err}
+	}
+	if st.Dev != pst.Dev {
+		return true, nil
+	}
+
+	mounts, err := GetMountInfoFiltered(SingleEntryFilter(path))
+	if err != nil {
+		return false, err
+	}
+	return len(mounts) > 0, nil
+}
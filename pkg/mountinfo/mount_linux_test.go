@@ -0,0 +1,32 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build linux
+
+package mountinfo
+
+import "testing"
+
+func TestMountedRoot(t *testing.T) {
+	// openat2+RESOLVE_NO_XDEV can only observe a mount boundary while
+	// crossing into the target component, so it can never see one at
+	// the root itself; Mounted must fall back to mountinfo for "/".
+	mounted, err := Mounted("/")
+	if err != nil {
+		t.Fatalf("Mounted(/): unexpected error: %s", err)
+	}
+	if !mounted {
+		t.Error("Mounted(/) = false, want true")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright 2021 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !linux
+
+package mountinfo
+
+// Mount is not implemented outside of Linux.
+func Mount(source, target, fstype string, flags uintptr, data string) error {
+	return ErrNotImplemented
+}
+
+// Unmount is not implemented outside of Linux.
+func Unmount(target string, flags int) error {
+	return ErrNotImplemented
+}
+
+// MakeShared is not implemented outside of Linux.
+func MakeShared(path string) error {
+	return ErrNotImplemented
+}
+
+// MakePrivate is not implemented outside of Linux.
+func MakePrivate(path string) error {
+	return ErrNotImplemented
+}
+
+// MakeRUnbindable is not implemented outside of Linux.
+func MakeRUnbindable(path string) error {
+	return ErrNotImplemented
+}
+
+// EnsureMount is not implemented outside of Linux.
+func EnsureMount(target, fstype string, magic int64) error {
+	return ErrNotImplemented
+}
+
+// Mounted is not implemented outside of Linux.
+func Mounted(path string) (bool, error) {
+	return false, ErrNotImplemented
+}
+
+// MountedType is not implemented outside of Linux.
+func MountedType(path string, mntType int64) (mounted, matches bool, err error) {
+	return false, false, ErrNotImplemented
+}
@@ -43,6 +43,8 @@ type MountInfo struct {
 	MountID        int64
 	ParentID       int64
 	StDev          string
+	Major          uint32
+	Minor          uint32
 	Root           string
 	MountPoint     string
 	MountOptions   string
@@ -52,9 +54,49 @@ type MountInfo struct {
 	SuperOptions   string
 }
 
+// octalEscaper replaces the octal escape sequences the kernel uses for
+// space, tab, newline and backslash in the "root" and "mount point" fields
+// of /proc/pid/mountinfo (see proc(5)) with the character they represent.
+var octalEscaper = strings.NewReplacer(
+	`\040`, " ",
+	`\011`, "\t",
+	`\012`, "\n",
+	`\134`, `\`,
+)
+
+// unescapeOctal decodes the octal escape sequences the kernel emits for
+// whitespace and backslash characters in mountinfo fields.
+func unescapeOctal(s string) string {
+	if !strings.ContainsRune(s, '\\') {
+		return s
+	}
+	return octalEscaper.Replace(s)
+}
+
+// parseStDev splits the "major:minor" st_dev field of a mountinfo entry
+// into its two components.
+func parseStDev(s string) (major, minor uint32, err error) {
+	majorStr, minorStr, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("invalid st_dev field: %s", s)
+	}
+
+	maj, err := strconv.ParseUint(majorStr, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid st_dev major in %q: %s", s, err)
+	}
+	min, err := strconv.ParseUint(minorStr, 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid st_dev minor in %q: %s", s, err)
+	}
+
+	return uint32(maj), uint32(min), nil
+}
+
 // parseMountInfoFile returns a slice of *MountInfo with information parsed from
-// the given reader
-func parseMountInfoFile(r io.Reader) ([]*MountInfo, error) {
+// the given reader, keeping only the entries that f lets through. A nil f
+// keeps every entry.
+func parseMountInfoFile(r io.Reader, f FilterFunc) ([]*MountInfo, error) {
 	var result []*MountInfo
 
 	scanner := bufio.NewScanner(r)
@@ -101,18 +143,38 @@ func parseMountInfoFile(r io.Reader) ([]*MountInfo, error) {
 			optionalFields = append(optionalFields, mountInfoLeft[i])
 		}
 
-		result = append(result, &MountInfo{
+		major, minor, err := parseStDev(mountInfoLeft[2])
+		if err != nil {
+			return nil, err
+		}
+
+		info := &MountInfo{
 			MountID:        mountID,
 			ParentID:       parentID,
 			StDev:          mountInfoLeft[2],
-			Root:           mountInfoLeft[3],
-			MountPoint:     mountInfoLeft[4],
+			Major:          major,
+			Minor:          minor,
+			Root:           unescapeOctal(mountInfoLeft[3]),
+			MountPoint:     unescapeOctal(mountInfoLeft[4]),
 			MountOptions:   mountInfoLeft[5],
 			OptionalFields: optionalFields,
 			FilesystemType: mountInfoRight[0],
 			MountSource:    mountInfoRight[1],
 			SuperOptions:   mountInfoRight[2],
-		})
+		}
+
+		if f == nil {
+			result = append(result, info)
+			continue
+		}
+
+		skip, stop := f(info)
+		if !skip {
+			result = append(result, info)
+		}
+		if stop {
+			break
+		}
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -122,16 +184,36 @@ func parseMountInfoFile(r io.Reader) ([]*MountInfo, error) {
 	return result, nil
 }
 
+// FilterFunc is a function that MountInfo entries are passed through. If
+// skip is true, the entry is excluded from the result; if stop is true, no
+// further entries are read, so a caller that has found what it is looking
+// for can avoid parsing (and allocating) the rest of the file.
+type FilterFunc func(*MountInfo) (skip, stop bool)
+
 // GetMountInfo returns a slice of *MountInfo with information parsed from
 // /proc/self/mountinfo
 func GetMountInfo() ([]*MountInfo, error) {
+	return GetMountInfoFiltered(nil)
+}
+
+// GetMountInfoFiltered returns a slice of *MountInfo with information parsed
+// from /proc/self/mountinfo, keeping only the entries f lets through. A nil
+// f keeps every entry, equivalent to GetMountInfo.
+func GetMountInfoFiltered(f FilterFunc) ([]*MountInfo, error) {
 	fMounts, err := os.Open(mountInfoFilepath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open mount information at %s: %s", mountInfoFilepath, err)
 	}
 	defer fMounts.Close()
 
-	return parseMountInfoFile(fMounts)
+	return GetMountInfoFromReader(fMounts, f)
+}
+
+// GetMountInfoFromReader returns a slice of *MountInfo with information
+// parsed from r, which must follow the /proc/pid/mountinfo syntax, keeping
+// only the entries f lets through. A nil f keeps every entry.
+func GetMountInfoFromReader(r io.Reader, f FilterFunc) ([]*MountInfo, error) {
+	return parseMountInfoFile(r, f)
 }
 
 // IsMountFS returns two boolean values, checking
@@ -0,0 +1,59 @@
+// Copyright 2018 Authors of Cilium
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mountinfo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnescapeOctal(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "/mnt/plain", want: "/mnt/plain"},
+		{in: `/mnt/with\040space`, want: "/mnt/with space"},
+		{in: `/mnt/with\011tab`, want: "/mnt/with\ttab"},
+		{in: `/mnt/with\012newline`, want: "/mnt/with\nnewline"},
+		{in: `/mnt/with\134backslash`, want: `/mnt/with\backslash`},
+		{in: `/mnt/a\040b\011c`, want: "/mnt/a b\tc"},
+	}
+
+	for _, tt := range tests {
+		if got := unescapeOctal(tt.in); got != tt.want {
+			t.Errorf("unescapeOctal(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseMountInfoFileUnescapesRootAndMountPoint(t *testing.T) {
+	const line = `18 15 0:6 /a\040b /mnt/weird\040dir rw - tmpfs tmpfs rw` + "\n"
+
+	infos, err := parseMountInfoFile(strings.NewReader(line), nil)
+	if err != nil {
+		t.Fatalf("parseMountInfoFile: unexpected error: %s", err)
+	}
+	if len(infos) != 1 {
+		t.Fatalf("parseMountInfoFile: got %d entries, want 1", len(infos))
+	}
+
+	info := infos[0]
+	if info.Root != "/a b" {
+		t.Errorf("Root = %q, want %q", info.Root, "/a b")
+	}
+	if info.MountPoint != "/mnt/weird dir" {
+		t.Errorf("MountPoint = %q, want %q", info.MountPoint, "/mnt/weird dir")
+	}
+}